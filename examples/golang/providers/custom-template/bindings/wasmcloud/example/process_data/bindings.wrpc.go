@@ -3,14 +3,12 @@ package process_data
 
 import (
 	context "context"
-	binary "encoding/binary"
-	errors "errors"
 	fmt "fmt"
 	wrpc "github.com/wrpc/wrpc/go"
 	io "io"
 	slog "log/slog"
-	math "math"
-	utf8 "unicode/utf8"
+
+	wrpccodec "github.com/wasmCloud/wasmCloud/examples/golang/providers/custom-template/wrpccodec"
 )
 
 type Data struct {
@@ -22,38 +20,11 @@ func (v *Data) String() string { return "Data" }
 
 func (v *Data) WriteTo(w wrpc.ByteWriter) error {
 	slog.Debug("writing field", "name", "name")
-	if err := func(v string, w wrpc.ByteWriter) error {
-		n := len(v)
-		if n > math.MaxUint32 {
-			return fmt.Errorf("string byte length of %d overflows a 32-bit integer", n)
-		}
-		slog.Debug("writing string byte length", "len", n)
-		if err := func(v uint32, w wrpc.ByteWriter) error {
-			b := make([]byte, binary.MaxVarintLen32)
-			i := binary.PutUvarint(b, uint64(v))
-			slog.Debug("writing u32")
-			_, err := w.Write(b[:i])
-			return err
-		}(uint32(n), w); err != nil {
-			return fmt.Errorf("failed to write string length of %d: %w", n, err)
-		}
-		slog.Debug("writing string bytes")
-		_, err := w.Write([]byte(v))
-		if err != nil {
-			return fmt.Errorf("failed to write string bytes: %w", err)
-		}
-		return nil
-	}(v.Name, w); err != nil {
+	if err := wrpccodec.WriteString(v.Name, w); err != nil {
 		return fmt.Errorf("failed to write `name` field: %w", err)
 	}
 	slog.Debug("writing field", "name", "count")
-	if err := func(v uint32, w wrpc.ByteWriter) error {
-		b := make([]byte, binary.MaxVarintLen32)
-		i := binary.PutUvarint(b, uint64(v))
-		slog.Debug("writing u32")
-		_, err := w.Write(b[:i])
-		return err
-	}(v.Count, w); err != nil {
+	if err := wrpccodec.WriteUvarint32(v.Count, w); err != nil {
 		return fmt.Errorf("failed to write `count` field: %w", err)
 	}
 	return nil
@@ -62,66 +33,12 @@ func ReadData(r wrpc.ByteReader) (*Data, error) {
 	v := &Data{}
 	var err error
 	slog.Debug("reading field", "name", "name")
-	v.Name, err = func(r wrpc.ByteReader) (string, error) {
-		var x uint32
-		var s uint
-		for i := 0; i < 5; i++ {
-			slog.Debug("reading string length byte", "i", i)
-			b, err := r.ReadByte()
-			if err != nil {
-				if i > 0 && err == io.EOF {
-					err = io.ErrUnexpectedEOF
-				}
-				return "", fmt.Errorf("failed to read string length byte: %w", err)
-			}
-			if b < 0x80 {
-				if i == 4 && b > 1 {
-					return "", errors.New("string length overflows a 32-bit integer")
-				}
-				x = x | uint32(b)<<s
-				buf := make([]byte, x)
-				slog.Debug("reading string bytes", "len", x)
-				_, err = r.Read(buf)
-				if err != nil {
-					return "", fmt.Errorf("failed to read string bytes: %w", err)
-				}
-				if !utf8.Valid(buf) {
-					return string(buf), errors.New("string is not valid UTF-8")
-				}
-				return string(buf), nil
-			}
-			x |= uint32(b&0x7f) << s
-			s += 7
-		}
-		return "", errors.New("string length overflows a 32-bit integer")
-	}(r)
+	v.Name, err = wrpccodec.ReadString(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read `name` field: %w", err)
 	}
 	slog.Debug("reading field", "name", "count")
-	v.Count, err = func(r wrpc.ByteReader) (uint32, error) {
-		var x uint32
-		var s uint
-		for i := 0; i < 5; i++ {
-			slog.Debug("reading `uint32` byte", "i", i)
-			b, err := r.ReadByte()
-			if err != nil {
-				if i > 0 && err == io.EOF {
-					err = io.ErrUnexpectedEOF
-				}
-				return x, fmt.Errorf("failed to read `uint32` byte: %w", err)
-			}
-			if b < 0x80 {
-				if i == 4 && b > 1 {
-					return x, errors.New("varint overflows a 32-bit integer")
-				}
-				return x | uint32(b)<<s, nil
-			}
-			x |= uint32(b&0x7f) << s
-			s += 7
-		}
-		return x, errors.New("varint overflows a 32-bit integer")
-	}(r)
+	v.Count, err = wrpccodec.ReadUvarint32(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read `count` field: %w", err)
 	}
@@ -130,14 +47,83 @@ func ReadData(r wrpc.ByteReader) (*Data, error) {
 
 // Send structured data to the component for processing
 func Process(ctx__ context.Context, wrpc__ wrpc.Client, data *Data) (r0__ string, err__ error) {
-	wrpc__.NewInvocation("wasmcloud:example/process-data", "process")
+	w__, r__, done__, err__ := wrpc__.NewInvocation(ctx__, "wasmcloud:example/process-data", "process")
+	if err__ != nil {
+		err__ = fmt.Errorf("failed to invoke `process`: %w", err__)
+		return
+	}
+	if err__ = data.WriteTo(w__); err__ != nil {
+		err__ = fmt.Errorf("failed to write `data` parameter: %w", err__)
+		return
+	}
+	if err__ = done__(); err__ != nil {
+		err__ = fmt.Errorf("failed to transmit parameters: %w", err__)
+		return
+	}
+	slog.Debug("reading result", "name", "result")
+	r0__, err__ = wrpccodec.ReadString(r__)
+	if err__ != nil {
+		err__ = fmt.Errorf("failed to read result: %w", err__)
+		return
+	}
+	return
+}
+
+// ProcessResult carries the outcome of an invocation started with
+// ProcessAsync.
+type ProcessResult struct {
+	Value string
+	Err   error
+}
+
+// ProcessHandle exposes the send and receive sides of an in-flight `process`
+// invocation separately, so a caller can stream `data` to Tx incrementally
+// (or interleave it with other invocations) instead of blocking on a single
+// round-trip as Process does. Tx is the write end of an in-memory pipe, not
+// the invocation's real wrpc.ByteWriter directly: writes to Tx only reach
+// the transport as the caller makes them, and closing Tx is what signals
+// "no more parameter bytes are coming", which in turn is what lets
+// ProcessAsync call the underlying transmission-complete callback. Writing
+// nothing and never closing Tx leaves the invocation open indefinitely.
+// Result delivers exactly one ProcessResult once the component has
+// responded or the invocation has failed.
+type ProcessHandle struct {
+	Tx     *io.PipeWriter
+	Result <-chan ProcessResult
+}
 
-	//if err != nil {
-	//    err__ = fmt.Sprintf("failed to invoke `process`: %w", txErr__)
-	//    return
-	//}
-	//wrpc__.1.await.context("failed to transmit parameters")?;
-	//Ok(tx__)
-	panic("not supported yet")
+// ProcessAsync starts the `process` invocation without writing `data` or
+// waiting for a result. The caller writes a `*Data` value to the returned
+// handle's Tx and calls Tx.Close when done; only once that close is
+// observed does ProcessAsync consider parameter transmission complete and
+// wait for the component's response. The decoded `string` return value (or
+// any transport/decode error) is delivered on Result.
+func ProcessAsync(ctx__ context.Context, wrpc__ wrpc.Client) (h__ *ProcessHandle, err__ error) {
+	w__, r__, done__, err__ := wrpc__.NewInvocation(ctx__, "wasmcloud:example/process-data", "process")
+	if err__ != nil {
+		err__ = fmt.Errorf("failed to invoke `process`: %w", err__)
+		return
+	}
+	pr__, pw__ := io.Pipe()
+	result__ := make(chan ProcessResult, 1)
+	go func() {
+		_, copyErr := io.Copy(w__, pr__)
+		pr__.CloseWithError(copyErr)
+		if copyErr != nil {
+			result__ <- ProcessResult{Err: fmt.Errorf("failed to write `data` parameter: %w", copyErr)}
+			return
+		}
+		if err := done__(); err != nil {
+			result__ <- ProcessResult{Err: fmt.Errorf("failed to transmit parameters: %w", err)}
+			return
+		}
+		slog.Debug("reading result", "name", "result")
+		v, err := wrpccodec.ReadString(r__)
+		if err != nil {
+			err = fmt.Errorf("failed to read result: %w", err)
+		}
+		result__ <- ProcessResult{Value: v, Err: err}
+	}()
+	h__ = &ProcessHandle{Tx: pw__, Result: result__}
 	return
 }
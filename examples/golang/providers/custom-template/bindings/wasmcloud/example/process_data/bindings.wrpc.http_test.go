@@ -0,0 +1,130 @@
+package process_data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	wrpc "github.com/wrpc/wrpc/go"
+
+	wrpccodec "github.com/wasmCloud/wasmCloud/examples/golang/providers/custom-template/wrpccodec"
+)
+
+func TestDataJSONRoundTrip(t *testing.T) {
+	in := &Data{Name: "widget", Count: 3}
+	b, err := in.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	if !strings.Contains(string(b), `"name":"widget"`) || !strings.Contains(string(b), `"count":3`) {
+		t.Fatalf("unexpected JSON: %s", b)
+	}
+
+	var out Data
+	if err := out.UnmarshalJSON(b); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if out != *in {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", out, *in)
+	}
+}
+
+func TestDataUnmarshalJSONInvalid(t *testing.T) {
+	var d Data
+	if err := d.UnmarshalJSON([]byte("not json")); err == nil {
+		t.Fatal("expected an error decoding invalid JSON")
+	}
+}
+
+// fakeInvocationClient implements wrpc.Client, returning result as the
+// encoded `process` return value on success, or failing NewInvocation with
+// err when set.
+type fakeInvocationClient struct {
+	result string
+	err    error
+}
+
+type discardWriter struct{ bytes.Buffer }
+
+func (c *fakeInvocationClient) NewInvocation(ctx context.Context, instance, name string) (wrpc.ByteWriter, wrpc.ByteReader, func() error, error) {
+	if c.err != nil {
+		return nil, nil, nil, c.err
+	}
+	var resultBuf discardWriter
+	if err := wrpccodec.WriteString(c.result, &resultBuf); err != nil {
+		return nil, nil, nil, err
+	}
+	return &discardWriter{}, &resultBuf.Buffer, func() error { return nil }, nil
+}
+
+func TestProcessHandlerHappyPath(t *testing.T) {
+	client := &fakeInvocationClient{result: "processed"}
+	srv := httptest.NewServer(ProcessHandler(client))
+	defer srv.Close()
+
+	body, _ := json.Marshal(map[string]any{"name": "widget", "count": 3})
+	resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var out map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if out["result"] != "processed" {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+}
+
+func TestProcessHandlerBadRequestBody(t *testing.T) {
+	client := &fakeInvocationClient{result: "unused"}
+	srv := httptest.NewServer(ProcessHandler(client))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader("not json"))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestProcessHandlerInvocationError(t *testing.T) {
+	client := &fakeInvocationClient{err: errors.New("no healthy endpoints available")}
+	srv := httptest.NewServer(ProcessHandler(client))
+	defer srv.Close()
+
+	body, _ := json.Marshal(map[string]any{"name": "widget", "count": 3})
+	resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", resp.StatusCode)
+	}
+}
+
+func TestRegisterGatewayMountsProcessRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterGateway(mux, &fakeInvocationClient{result: "ok"})
+
+	body, _ := json.Marshal(map[string]any{"name": "widget", "count": 1})
+	req := httptest.NewRequest(http.MethodPost, "/wasmcloud.example/process-data/process", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,90 @@
+package process_data
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	wrpc "github.com/wrpc/wrpc/go"
+
+	wrpccodec "github.com/wasmCloud/wasmCloud/examples/golang/providers/custom-template/wrpccodec"
+)
+
+// asyncFakeClient is a wrpc.Client whose done__ callback records when it was
+// called, so tests can assert it fires only after the caller finishes
+// writing to the handle's Tx.
+type asyncFakeClient struct {
+	doneCalledAt atomic.Value // time.Time
+	result       string
+}
+
+func (c *asyncFakeClient) NewInvocation(ctx context.Context, instance, name string) (wrpc.ByteWriter, wrpc.ByteReader, func() error, error) {
+	var resultBuf bytes.Buffer
+	if err := wrpccodec.WriteString(c.result, &resultBuf); err != nil {
+		return nil, nil, nil, err
+	}
+	var tx bytes.Buffer
+	done := func() error {
+		c.doneCalledAt.Store(time.Now())
+		return nil
+	}
+	return &tx, &resultBuf, done, nil
+}
+
+func TestProcessAsyncDoesNotSignalDoneBeforeCallerWrites(t *testing.T) {
+	client := &asyncFakeClient{result: "processed"}
+
+	h, err := ProcessAsync(context.Background(), client)
+	if err != nil {
+		t.Fatalf("ProcessAsync: %v", err)
+	}
+
+	writeAt := time.Now().Add(20 * time.Millisecond)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		data := &Data{Name: "widget", Count: 1}
+		if err := data.WriteTo(h.Tx); err != nil {
+			t.Errorf("WriteTo: %v", err)
+		}
+		h.Tx.Close()
+	}()
+
+	res := <-h.Result
+	if res.Err != nil {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+	if res.Value != "processed" {
+		t.Fatalf("unexpected result: %q", res.Value)
+	}
+
+	doneCalledAt, ok := client.doneCalledAt.Load().(time.Time)
+	if !ok {
+		t.Fatal("expected done__ to have been called")
+	}
+	if doneCalledAt.Before(writeAt) {
+		t.Fatalf("done__ was called at %v, before the caller finished writing at %v", doneCalledAt, writeAt)
+	}
+}
+
+func TestProcessAsyncPropagatesWriterErrorWithoutSignalingDone(t *testing.T) {
+	client := &asyncFakeClient{result: "unused"}
+
+	h, err := ProcessAsync(context.Background(), client)
+	if err != nil {
+		t.Fatalf("ProcessAsync: %v", err)
+	}
+
+	wantErr := io.ErrClosedPipe
+	h.Tx.CloseWithError(wantErr)
+
+	res := <-h.Result
+	if res.Err == nil {
+		t.Fatal("expected an error when the caller aborts the write side")
+	}
+	if client.doneCalledAt.Load() != nil {
+		t.Fatal("done__ must not be called when transmission failed")
+	}
+}
@@ -0,0 +1,270 @@
+// Package wrpcclient provides a multi-endpoint wrapper around wrpc.Client
+// for generated bindings that want failover or load-balancing across
+// several wasmCloud hosts instead of a single fixed connection.
+package wrpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	wrpc "github.com/wrpc/wrpc/go"
+)
+
+// Policy selects which Endpoint a BalancedClient tries next.
+type Policy int
+
+const (
+	// RoundRobin cycles through healthy endpoints in order.
+	RoundRobin Policy = iota
+	// PickFirst always prefers the first healthy endpoint, falling back
+	// to the next one only when it is ejected or exhausted.
+	PickFirst
+	// Weighted picks a healthy endpoint at random, proportional to its
+	// Endpoint.Weight.
+	Weighted
+)
+
+// Endpoint is one underlying wrpc.Client a BalancedClient can route
+// invocations to.
+type Endpoint struct {
+	Client wrpc.Client
+	// Weight is only consulted under the Weighted policy. Endpoints with
+	// Weight <= 0 are treated as 1.
+	Weight int
+}
+
+// Resolver produces the current set of endpoints a BalancedClient should
+// rotate through. Implementations may return a fixed list (e.g. a static
+// resolver built at startup) or re-resolve targets on every call, the same
+// way a DNS SRV lookup or a NATS subject discovery query would.
+type Resolver interface {
+	Resolve(ctx context.Context) ([]Endpoint, error)
+}
+
+// StaticResolver is a Resolver over a fixed list of endpoints, for callers
+// who already know their targets (as opposed to discovering them via DNS
+// SRV records or a NATS subject, which can be added by implementing
+// Resolver directly).
+type StaticResolver struct {
+	endpoints []Endpoint
+}
+
+// NewStaticResolver returns a Resolver that always resolves to endpoints.
+func NewStaticResolver(endpoints ...Endpoint) *StaticResolver {
+	return &StaticResolver{endpoints: endpoints}
+}
+
+func (r *StaticResolver) Resolve(context.Context) ([]Endpoint, error) {
+	return r.endpoints, nil
+}
+
+const (
+	ejectionBackoffMin = 500 * time.Millisecond
+	ejectionBackoffMax = 30 * time.Second
+)
+
+// health tracks consecutive transport-level failures for one endpoint.
+// recordFailure grows ejectedUntil exponentially so a host that keeps
+// failing is left out of rotation for longer, and recordSuccess clears it
+// immediately so a recovered host is eligible again right away.
+type health struct {
+	mu           sync.Mutex
+	failures     int
+	ejectedUntil time.Time
+}
+
+func (h *health) healthy() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Now().After(h.ejectedUntil)
+}
+
+func (h *health) recordSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures = 0
+	h.ejectedUntil = time.Time{}
+}
+
+func (h *health) recordFailure() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures++
+	backoff := ejectionBackoffMin << uint(h.failures-1)
+	if backoff > ejectionBackoffMax || backoff <= 0 {
+		backoff = ejectionBackoffMax
+	}
+	h.ejectedUntil = time.Now().Add(backoff)
+}
+
+// BalancedClient implements wrpc.Client over N underlying endpoints,
+// retrying invocations that fail for transport-level reasons (connection
+// closed, timeout, NATS no-responders) on the next endpoint up to Retries
+// attempts. Application-level errors returned by the component are
+// surfaced to the caller immediately, without retrying against another
+// endpoint, since retrying would re-run a component invocation that may
+// already have had side effects.
+type BalancedClient struct {
+	Policy  Policy
+	Retries int
+
+	resolver Resolver
+
+	mu     sync.Mutex
+	next   int
+	health map[wrpc.Client]*health
+}
+
+// NewBalancedClient constructs a BalancedClient that resolves its endpoints
+// via resolver and distributes invocations according to policy. retries is
+// the number of additional endpoints to try after a transport-level
+// failure; 0 disables failover.
+func NewBalancedClient(resolver Resolver, policy Policy, retries int) *BalancedClient {
+	return &BalancedClient{
+		Policy:   policy,
+		Retries:  retries,
+		resolver: resolver,
+		health:   make(map[wrpc.Client]*health),
+	}
+}
+
+// IsTransportError reports whether err should trigger failover to the next
+// endpoint rather than being surfaced to the caller. It recognizes a
+// closed/canceled context or stream and NATS "no responders" errors;
+// anything else is assumed to be an application-level error from the
+// component itself.
+func IsTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.ErrClosedPipe) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	var transportErr interface{ Transport() bool }
+	if errors.As(err, &transportErr) {
+		return transportErr.Transport()
+	}
+	return false
+}
+
+func (c *BalancedClient) healthFor(ep wrpc.Client) *health {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h, ok := c.health[ep]
+	if !ok {
+		h = &health{}
+		c.health[ep] = h
+	}
+	return h
+}
+
+// weightOf returns ep.Weight, treating Weight <= 0 as 1 per Endpoint's doc.
+func weightOf(ep Endpoint) int {
+	if ep.Weight <= 0 {
+		return 1
+	}
+	return ep.Weight
+}
+
+// order returns the candidate endpoints in the order they should be tried,
+// per Policy, skipping none up-front so a fully ejected pool still attempts
+// its first choice rather than failing closed.
+func (c *BalancedClient) order(endpoints []Endpoint) []Endpoint {
+	switch c.Policy {
+	case PickFirst:
+		return endpoints
+	case Weighted:
+		// Weighted sampling without replacement: repeatedly draw from the
+		// remaining pool with probability proportional to Weight, so
+		// heavier endpoints are tried earlier more often without being
+		// tried exclusively.
+		remaining := make([]Endpoint, len(endpoints))
+		copy(remaining, endpoints)
+		ordered := make([]Endpoint, 0, len(remaining))
+		for len(remaining) > 0 {
+			total := 0
+			for _, ep := range remaining {
+				total += weightOf(ep)
+			}
+			pick := rand.Intn(total)
+			idx := 0
+			for pick >= weightOf(remaining[idx]) {
+				pick -= weightOf(remaining[idx])
+				idx++
+			}
+			ordered = append(ordered, remaining[idx])
+			remaining = append(remaining[:idx], remaining[idx+1:]...)
+		}
+		return ordered
+	case RoundRobin:
+		fallthrough
+	default:
+		c.mu.Lock()
+		start := c.next % len(endpoints)
+		c.next++
+		c.mu.Unlock()
+		rotated := make([]Endpoint, len(endpoints))
+		for i := range endpoints {
+			rotated[i] = endpoints[(start+i)%len(endpoints)]
+		}
+		return rotated
+	}
+}
+
+// NewInvocation implements wrpc.Client, trying endpoints returned by the
+// Resolver in Policy order until one accepts the invocation or Retries is
+// exhausted.
+func (c *BalancedClient) NewInvocation(ctx context.Context, instance, name string) (wrpc.ByteWriter, wrpc.ByteReader, func() error, error) {
+	endpoints, err := c.resolver.Resolve(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to resolve endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil, nil, nil, errors.New("no endpoints available")
+	}
+
+	candidates := c.order(endpoints)
+	maxAttempts := c.Retries + 1
+	if maxAttempts > len(candidates) {
+		maxAttempts = len(candidates)
+	}
+
+	var lastErr error
+	tried := 0
+	for i := 0; i < len(candidates) && tried < maxAttempts; i++ {
+		ep := candidates[i]
+		h := c.healthFor(ep.Client)
+		if !h.healthy() {
+			// Skip an ejected endpoint for free as long as enough
+			// candidates remain to still fill the attempt budget; once
+			// they don't, try it anyway rather than spending the budget
+			// on no-op skips and failing closed with endpoints left
+			// untried.
+			remaining := len(candidates) - i
+			if remaining > maxAttempts-tried {
+				continue
+			}
+		}
+		tried++
+		w, r, done, err := ep.Client.NewInvocation(ctx, instance, name)
+		if err == nil {
+			h.recordSuccess()
+			return w, r, done, nil
+		}
+		if !IsTransportError(err) {
+			return nil, nil, nil, err
+		}
+		h.recordFailure()
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no healthy endpoints available")
+	}
+	return nil, nil, nil, fmt.Errorf("all endpoints failed: %w", lastErr)
+}
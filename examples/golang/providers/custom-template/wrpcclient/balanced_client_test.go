@@ -0,0 +1,192 @@
+package wrpcclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	wrpc "github.com/wrpc/wrpc/go"
+)
+
+type fakeTransportErr struct{}
+
+func (fakeTransportErr) Error() string   { return "connection closed" }
+func (fakeTransportErr) Transport() bool { return true }
+
+// fakeClient is a wrpc.Client whose NewInvocation is scripted by fail: a
+// nil fail succeeds, otherwise it returns fail as the invocation error.
+type fakeClient struct {
+	fail   error
+	called int
+}
+
+func (c *fakeClient) NewInvocation(ctx context.Context, instance, name string) (wrpc.ByteWriter, wrpc.ByteReader, func() error, error) {
+	c.called++
+	if c.fail != nil {
+		return nil, nil, nil, c.fail
+	}
+	return nil, nil, func() error { return nil }, nil
+}
+
+func TestOrderRoundRobinRotatesAcrossCalls(t *testing.T) {
+	ep0 := Endpoint{Client: &fakeClient{}}
+	ep1 := Endpoint{Client: &fakeClient{}}
+	ep2 := Endpoint{Client: &fakeClient{}}
+	endpoints := []Endpoint{ep0, ep1, ep2}
+
+	c := &BalancedClient{Policy: RoundRobin}
+	var starts []wrpc.Client
+	for i := 0; i < 6; i++ {
+		ordered := c.order(endpoints)
+		starts = append(starts, ordered[0].Client)
+	}
+	want := []wrpc.Client{ep0.Client, ep1.Client, ep2.Client, ep0.Client, ep1.Client, ep2.Client}
+	for i := range want {
+		if starts[i] != want[i] {
+			t.Fatalf("call %d: expected endpoint %v first, got %v", i, want[i], starts[i])
+		}
+	}
+}
+
+func TestOrderPickFirstReturnsEndpointsUnchanged(t *testing.T) {
+	endpoints := []Endpoint{{Client: &fakeClient{}}, {Client: &fakeClient{}}}
+	c := &BalancedClient{Policy: PickFirst}
+	ordered := c.order(endpoints)
+	for i := range endpoints {
+		if ordered[i].Client != endpoints[i].Client {
+			t.Fatalf("PickFirst reordered endpoints: index %d changed", i)
+		}
+	}
+}
+
+func TestOrderWeightedSkewsTowardHeavierEndpoint(t *testing.T) {
+	heavy := Endpoint{Client: &fakeClient{}, Weight: 99}
+	light := Endpoint{Client: &fakeClient{}, Weight: 1}
+	c := &BalancedClient{Policy: Weighted}
+
+	const trials = 2000
+	heavyFirst := 0
+	for i := 0; i < trials; i++ {
+		if c.order([]Endpoint{heavy, light})[0].Client == heavy.Client {
+			heavyFirst++
+		}
+	}
+	// A uniform shuffle would land close to 50%; weight 99 vs 1 should push
+	// this well above that.
+	if heavyFirst < trials*80/100 {
+		t.Fatalf("expected heavy endpoint first in >=80%% of %d trials, got %d", trials, heavyFirst)
+	}
+}
+
+func TestHealthBackoffGrowsThenResetsOnSuccess(t *testing.T) {
+	h := &health{}
+	if !h.healthy() {
+		t.Fatal("fresh health should start healthy")
+	}
+
+	h.recordFailure()
+	firstEjectedUntil := h.ejectedUntil
+	if !firstEjectedUntil.After(time.Now()) {
+		t.Fatal("expected first failure to eject the endpoint")
+	}
+
+	h.recordFailure()
+	if !h.ejectedUntil.After(firstEjectedUntil) {
+		t.Fatalf("expected backoff to grow on a second consecutive failure: first=%v second=%v", firstEjectedUntil, h.ejectedUntil)
+	}
+
+	h.recordSuccess()
+	if !h.healthy() {
+		t.Fatal("expected recordSuccess to clear ejection immediately")
+	}
+	if h.failures != 0 {
+		t.Fatalf("expected recordSuccess to reset the failure count, got %d", h.failures)
+	}
+}
+
+func TestNewInvocationPickFirstFallsBackPastEjectedEndpoints(t *testing.T) {
+	ep0 := &fakeClient{}
+	ep1 := &fakeClient{}
+	ep2 := &fakeClient{}
+	c := NewBalancedClient(NewStaticResolver(
+		Endpoint{Client: ep0},
+		Endpoint{Client: ep1},
+		Endpoint{Client: ep2},
+	), PickFirst, 0)
+	c.healthFor(ep0).recordFailure()
+	c.healthFor(ep1).recordFailure()
+
+	_, _, _, err := c.NewInvocation(context.Background(), "inst", "fn")
+	if err != nil {
+		t.Fatalf("expected the healthy 3rd endpoint to be used, got error: %v", err)
+	}
+	if ep2.called != 1 {
+		t.Fatalf("expected the healthy endpoint to be invoked exactly once, got %d", ep2.called)
+	}
+	if ep0.called != 0 || ep1.called != 0 {
+		t.Fatalf("ejected endpoints should not have been invoked: ep0=%d ep1=%d", ep0.called, ep1.called)
+	}
+}
+
+func TestNewInvocationTriesAnEndpointEvenWhenAllAreEjected(t *testing.T) {
+	ep0 := &fakeClient{fail: fakeTransportErr{}}
+	ep1 := &fakeClient{fail: fakeTransportErr{}}
+	c := NewBalancedClient(NewStaticResolver(
+		Endpoint{Client: ep0},
+		Endpoint{Client: ep1},
+	), PickFirst, 0)
+	c.healthFor(ep0).recordFailure()
+	c.healthFor(ep1).recordFailure()
+
+	_, _, _, err := c.NewInvocation(context.Background(), "inst", "fn")
+	if err == nil {
+		t.Fatal("expected an error: both endpoints fail")
+	}
+	if ep0.called+ep1.called == 0 {
+		t.Fatal("expected at least one ejected endpoint to actually be invoked instead of failing closed on skips alone")
+	}
+	if errors.Unwrap(err) == nil {
+		t.Fatalf("expected a wrapped, non-nil cause, got: %v", err)
+	}
+}
+
+func TestNewInvocationRetriesBudgetCoversOnlyRealAttempts(t *testing.T) {
+	ep0 := &fakeClient{fail: fakeTransportErr{}}
+	ep1 := &fakeClient{}
+	ep2 := &fakeClient{}
+	c := NewBalancedClient(NewStaticResolver(
+		Endpoint{Client: ep0},
+		Endpoint{Client: ep1},
+		Endpoint{Client: ep2},
+	), PickFirst, 1)
+	// ep2 is pre-ejected but should never need to be tried: ep0 fails
+	// (consuming one of the two attempts), ep1 is healthy and succeeds on
+	// the second attempt.
+	c.healthFor(ep2).recordFailure()
+
+	_, _, _, err := c.NewInvocation(context.Background(), "inst", "fn")
+	if err != nil {
+		t.Fatalf("expected success on the 2nd attempt, got: %v", err)
+	}
+	if ep0.called != 1 || ep1.called != 1 || ep2.called != 0 {
+		t.Fatalf("expected exactly ep0 then ep1 to be tried, got ep0=%d ep1=%d ep2=%d", ep0.called, ep1.called, ep2.called)
+	}
+}
+
+func TestNewInvocationApplicationErrorIsNotRetried(t *testing.T) {
+	ep0 := &fakeClient{fail: errors.New("component rejected the request")}
+	ep1 := &fakeClient{}
+	c := NewBalancedClient(NewStaticResolver(
+		Endpoint{Client: ep0},
+		Endpoint{Client: ep1},
+	), PickFirst, 1)
+
+	_, _, _, err := c.NewInvocation(context.Background(), "inst", "fn")
+	if err == nil || err.Error() != "component rejected the request" {
+		t.Fatalf("expected the application error surfaced unchanged, got: %v", err)
+	}
+	if ep1.called != 0 {
+		t.Fatal("application-level errors must not trigger failover to the next endpoint")
+	}
+}
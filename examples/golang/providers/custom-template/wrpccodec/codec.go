@@ -0,0 +1,132 @@
+// Package wrpccodec holds the varint and string wire-format helpers that
+// `wit-bindgen-wrpc-go` used to inline into every generated WriteTo/Read*
+// function. Generated code now calls these instead of repeating the
+// encoding/decoding loop per field.
+package wrpccodec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"unicode/utf8"
+
+	wrpc "github.com/wrpc/wrpc/go"
+)
+
+// WriteUvarint32 writes v as a LEB128-style unsigned varint, the wire
+// format WIT uses for u8/u16/u32 and for length prefixes.
+func WriteUvarint32(v uint32, w wrpc.ByteWriter) error {
+	b := make([]byte, binary.MaxVarintLen32)
+	i := binary.PutUvarint(b, uint64(v))
+	slog.Debug("writing u32")
+	if _, err := w.Write(b[:i]); err != nil {
+		return fmt.Errorf("failed to write varint: %w", err)
+	}
+	return nil
+}
+
+// ReadUvarint32 reads a varint written by WriteUvarint32, rejecting inputs
+// whose 5th continuation byte would overflow 32 bits and treating an EOF
+// after at least one byte as a truncated read.
+func ReadUvarint32(r wrpc.ByteReader) (uint32, error) {
+	var x uint32
+	var s uint
+	for i := 0; i < binary.MaxVarintLen32; i++ {
+		slog.Debug("reading varint byte", "i", i)
+		b, err := r.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return x, fmt.Errorf("failed to read varint byte: %w", err)
+		}
+		if b < 0x80 {
+			if i == binary.MaxVarintLen32-1 && b > 0x0f {
+				return x, errors.New("varint overflows a 32-bit integer")
+			}
+			return x | uint32(b)<<s, nil
+		}
+		x |= uint32(b&0x7f) << s
+		s += 7
+	}
+	return x, errors.New("varint overflows a 32-bit integer")
+}
+
+// WriteBytes writes v as a varint length prefix followed by the raw bytes.
+func WriteBytes(v []byte, w wrpc.ByteWriter) error {
+	n := len(v)
+	if n > math.MaxUint32 {
+		return fmt.Errorf("byte length of %d overflows a 32-bit integer", n)
+	}
+	if err := WriteUvarint32(uint32(n), w); err != nil {
+		return fmt.Errorf("failed to write byte length of %d: %w", n, err)
+	}
+	if _, err := w.Write(v); err != nil {
+		return fmt.Errorf("failed to write bytes: %w", err)
+	}
+	return nil
+}
+
+// readChunkSize bounds how much ReadBytes allocates up front for a single
+// length-prefixed value, regardless of how large the (attacker-controlled)
+// length prefix claims to be; it grows the result only as bytes actually
+// arrive on the wire.
+const readChunkSize = 32 * 1024
+
+func minUint32(a, b uint32) uint32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// ReadBytes reads a varint length prefix followed by that many raw bytes.
+// It returns io.ErrUnexpectedEOF if fewer bytes are available than the
+// length prefix declares, instead of silently returning a short buffer, and
+// reads in bounded chunks so a bogus multi-gigabyte length prefix can't be
+// used to force a multi-gigabyte allocation before any of that data has
+// actually been received.
+func ReadBytes(r wrpc.ByteReader) ([]byte, error) {
+	n, err := ReadUvarint32(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read byte length: %w", err)
+	}
+	buf := make([]byte, 0, minUint32(n, readChunkSize))
+	chunk := make([]byte, minUint32(n, readChunkSize))
+	for uint32(len(buf)) < n {
+		want := minUint32(n-uint32(len(buf)), readChunkSize)
+		read, err := io.ReadFull(r, chunk[:want])
+		if err != nil {
+			if err == io.ErrUnexpectedEOF || err == io.EOF {
+				return nil, fmt.Errorf("failed to read %d bytes, got %d: %w", n, len(buf)+read, io.ErrUnexpectedEOF)
+			}
+			return nil, fmt.Errorf("failed to read bytes: %w", err)
+		}
+		buf = append(buf, chunk[:read]...)
+	}
+	return buf, nil
+}
+
+// WriteString writes v as a varint UTF-8 byte length followed by its bytes.
+func WriteString(v string, w wrpc.ByteWriter) error {
+	if err := WriteBytes([]byte(v), w); err != nil {
+		return fmt.Errorf("failed to write string: %w", err)
+	}
+	return nil
+}
+
+// ReadString reads a string written by WriteString and validates that its
+// bytes are well-formed UTF-8.
+func ReadString(r wrpc.ByteReader) (string, error) {
+	buf, err := ReadBytes(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read string bytes: %w", err)
+	}
+	if !utf8.Valid(buf) {
+		return string(buf), errors.New("string is not valid UTF-8")
+	}
+	return string(buf), nil
+}
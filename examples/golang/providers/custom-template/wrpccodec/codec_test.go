@@ -0,0 +1,165 @@
+package wrpccodec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+// byteBuffer adapts bytes.Buffer to wrpc.ByteWriter/wrpc.ByteReader for
+// tests, since bytes.Buffer already implements Write, Read, and ReadByte.
+type byteBuffer struct {
+	bytes.Buffer
+}
+
+func TestUvarint32RoundTrip(t *testing.T) {
+	for _, v := range []uint32{0, 1, 127, 128, 300, 1 << 20, 1<<32 - 1} {
+		var buf byteBuffer
+		if err := WriteUvarint32(v, &buf); err != nil {
+			t.Fatalf("WriteUvarint32(%d): %v", v, err)
+		}
+		got, err := ReadUvarint32(&buf)
+		if err != nil {
+			t.Fatalf("ReadUvarint32(%d): %v", v, err)
+		}
+		if got != v {
+			t.Fatalf("round trip mismatch: wrote %d, read %d", v, got)
+		}
+	}
+}
+
+func TestUvarint32OversizedLength(t *testing.T) {
+	// Five continuation bytes whose final byte is > 1 overflow 32 bits and
+	// must be rejected rather than silently wrapping.
+	buf := byteBuffer{Buffer: *bytes.NewBuffer([]byte{0xff, 0xff, 0xff, 0xff, 0x1f})}
+	if _, err := ReadUvarint32(&buf); err == nil {
+		t.Fatal("expected overflow error, got nil")
+	}
+}
+
+func TestUvarint32TruncatedInput(t *testing.T) {
+	// A continuation byte (high bit set) with nothing following must
+	// surface io.ErrUnexpectedEOF, not a silent zero value.
+	buf := byteBuffer{Buffer: *bytes.NewBuffer([]byte{0x80})}
+	_, err := ReadUvarint32(&buf)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, v := range []string{"", "hello", "éèê", "very long string used to exercise multi-byte length prefixes ...................."} {
+		var buf byteBuffer
+		if err := WriteString(v, &buf); err != nil {
+			t.Fatalf("WriteString(%q): %v", v, err)
+		}
+		got, err := ReadString(&buf)
+		if err != nil {
+			t.Fatalf("ReadString(%q): %v", v, err)
+		}
+		if got != v {
+			t.Fatalf("round trip mismatch: wrote %q, read %q", v, got)
+		}
+	}
+}
+
+func TestReadStringInvalidUTF8(t *testing.T) {
+	var buf byteBuffer
+	if err := WriteBytes([]byte{0xff, 0xfe}, &buf); err != nil {
+		t.Fatalf("WriteBytes: %v", err)
+	}
+	if _, err := ReadString(&buf); err == nil {
+		t.Fatal("expected invalid UTF-8 error, got nil")
+	}
+}
+
+func TestReadBytesTruncatedBody(t *testing.T) {
+	// Length prefix claims more bytes than are actually present; ReadBytes
+	// must report this instead of returning a short buffer.
+	var buf byteBuffer
+	if err := WriteUvarint32(10, &buf); err != nil {
+		t.Fatalf("WriteUvarint32: %v", err)
+	}
+	buf.Write([]byte{1, 2, 3})
+	if _, err := ReadBytes(&buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestReadBytesOversizedLengthDoesNotAllocateUpFront(t *testing.T) {
+	// A claimed length of ~4GiB with only a handful of bytes behind it
+	// must fail fast on the truncated body instead of allocating gigabytes
+	// before discovering the peer never sent that much.
+	var buf byteBuffer
+	if err := WriteUvarint32(math.MaxUint32, &buf); err != nil {
+		t.Fatalf("WriteUvarint32: %v", err)
+	}
+	buf.Write([]byte{1, 2, 3})
+	if _, err := ReadBytes(&buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func FuzzReadBytesMalformed(f *testing.F) {
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 1, 2, 3})
+	f.Add([]byte{10, 1, 2, 3})
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		buf := byteBuffer{Buffer: *bytes.NewBuffer(data)}
+		// Must never panic, and must never block attempting to allocate
+		// or read an unbounded amount regardless of the claimed length.
+		_, _ = ReadBytes(&buf)
+	})
+}
+
+func FuzzUvarint32RoundTrip(f *testing.F) {
+	for _, v := range []uint32{0, 1, 127, 128, 1 << 20, 1<<32 - 1} {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, v uint32) {
+		var buf byteBuffer
+		if err := WriteUvarint32(v, &buf); err != nil {
+			t.Fatalf("WriteUvarint32(%d): %v", v, err)
+		}
+		got, err := ReadUvarint32(&buf)
+		if err != nil {
+			t.Fatalf("ReadUvarint32(%d): %v", v, err)
+		}
+		if got != v {
+			t.Fatalf("round trip mismatch: wrote %d, read %d", v, got)
+		}
+	})
+}
+
+func FuzzReadUvarint32Malformed(f *testing.F) {
+	f.Add([]byte{0x80})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0x1f})
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		buf := byteBuffer{Buffer: *bytes.NewBuffer(data)}
+		// Must never panic on arbitrary input, regardless of whether it
+		// decodes successfully.
+		_, _ = ReadUvarint32(&buf)
+	})
+}
+
+func FuzzStringRoundTrip(f *testing.F) {
+	f.Add("")
+	f.Add("hello")
+	f.Add("éèê")
+	f.Fuzz(func(t *testing.T, v string) {
+		var buf byteBuffer
+		if err := WriteString(v, &buf); err != nil {
+			t.Fatalf("WriteString(%q): %v", v, err)
+		}
+		got, err := ReadString(&buf)
+		if err != nil {
+			t.Fatalf("ReadString(%q): %v", v, err)
+		}
+		if got != v {
+			t.Fatalf("round trip mismatch: wrote %q, read %q", v, got)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Package wrpctypes is a runtime registry of the WIT types emitted by
+// `wit-bindgen-wrpc-go`, so generic middleware (logging, tracing, schema
+// validation, JSON transcoding, replayable invocation recorders) can encode,
+// decode, and introspect generated types without a hand-written codec for
+// every WIT interface.
+package wrpctypes
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	wrpc "github.com/wrpc/wrpc/go"
+)
+
+// FieldKind identifies the WIT type kind of one field of a record, variant,
+// or enum.
+type FieldKind int
+
+const (
+	FieldKindBool FieldKind = iota
+	FieldKindU8
+	FieldKindU16
+	FieldKindU32
+	FieldKindU64
+	FieldKindS8
+	FieldKindS16
+	FieldKindS32
+	FieldKindS64
+	FieldKindF32
+	FieldKindF64
+	FieldKindString
+	FieldKindRecord
+	FieldKindVariant
+	FieldKindEnum
+	FieldKindList
+	FieldKindOption
+)
+
+// FieldDescriptor describes one field of a registered type.
+type FieldDescriptor struct {
+	Name   string
+	Kind   FieldKind
+	Offset uintptr
+}
+
+// TypeInfo is the registered description of a single WIT record, variant,
+// or enum: its WIT name, the Go type the generator produced for it, its
+// fields, and generic codec functions that dispatch to the type's
+// hand-written WriteTo/Read* functions.
+type TypeInfo struct {
+	WITName string
+	GoType  reflect.Type
+	Fields  []FieldDescriptor
+	Encode  func(v any, w wrpc.ByteWriter) error
+	Decode  func(r wrpc.ByteReader) (any, error)
+}
+
+var (
+	mu        sync.RWMutex
+	byWITName = map[string]TypeInfo{}
+	byGoType  = map[reflect.Type]TypeInfo{}
+)
+
+// Register adds info to the registry. Generated packages call this from an
+// init() function, so every package imported for its side effects alone
+// contributes its types before any plugin looks them up.
+func Register(info TypeInfo) {
+	mu.Lock()
+	defer mu.Unlock()
+	byWITName[info.WITName] = info
+	byGoType[info.GoType] = info
+}
+
+// Lookup finds a registered TypeInfo by its WIT name, e.g.
+// "wasmcloud:example/process-data#data".
+func Lookup(witName string) (TypeInfo, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	info, ok := byWITName[witName]
+	return info, ok
+}
+
+// TypeOf finds the registered TypeInfo for the Go type of v.
+func TypeOf(v any) (TypeInfo, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	info, ok := byGoType[reflect.TypeOf(v)]
+	return info, ok
+}
+
+// Encode looks up v's registered type and encodes it to w.
+func Encode(v any, w wrpc.ByteWriter) error {
+	info, ok := TypeOf(v)
+	if !ok {
+		return fmt.Errorf("no registered TypeInfo for %T", v)
+	}
+	return info.Encode(v, w)
+}
+
+// Decode reads a value of the WIT type named witName from r.
+func Decode(witName string, r wrpc.ByteReader) (any, error) {
+	info, ok := Lookup(witName)
+	if !ok {
+		return nil, fmt.Errorf("no registered TypeInfo for %q", witName)
+	}
+	return info.Decode(r)
+}
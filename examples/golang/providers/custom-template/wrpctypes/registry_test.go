@@ -0,0 +1,123 @@
+package wrpctypes
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	wrpc "github.com/wrpc/wrpc/go"
+)
+
+type exampleRecord struct {
+	Name string
+}
+
+func registerExampleRecord(t *testing.T) {
+	t.Helper()
+	Register(TypeInfo{
+		WITName: "test:example/registry-test#example-record",
+		GoType:  reflect.TypeOf(exampleRecord{}),
+		Fields: []FieldDescriptor{
+			{Name: "name", Kind: FieldKindString},
+		},
+		Encode: func(v any, w wrpc.ByteWriter) error {
+			_, err := w.Write([]byte(v.(exampleRecord).Name))
+			return err
+		},
+		Decode: func(r wrpc.ByteReader) (any, error) {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return nil, err
+			}
+			return exampleRecord{Name: buf.String()}, nil
+		},
+	})
+}
+
+func TestRegisterLookupByWITName(t *testing.T) {
+	registerExampleRecord(t)
+
+	info, ok := Lookup("test:example/registry-test#example-record")
+	if !ok {
+		t.Fatal("expected Lookup to find the registered type")
+	}
+	if info.GoType != reflect.TypeOf(exampleRecord{}) {
+		t.Fatalf("unexpected GoType: %v", info.GoType)
+	}
+}
+
+func TestLookupUnknownWITName(t *testing.T) {
+	if _, ok := Lookup("test:example/registry-test#does-not-exist"); ok {
+		t.Fatal("expected Lookup to report no match for an unregistered name")
+	}
+}
+
+func TestTypeOfDispatchesOnGoType(t *testing.T) {
+	registerExampleRecord(t)
+
+	info, ok := TypeOf(exampleRecord{Name: "widget"})
+	if !ok {
+		t.Fatal("expected TypeOf to find the registered type")
+	}
+	if info.WITName != "test:example/registry-test#example-record" {
+		t.Fatalf("unexpected WITName: %q", info.WITName)
+	}
+
+	if _, ok := TypeOf("not a registered type"); ok {
+		t.Fatal("expected TypeOf to report no match for an unregistered Go type")
+	}
+}
+
+func TestEncodeDispatchesToRegisteredEncoder(t *testing.T) {
+	registerExampleRecord(t)
+
+	var buf bytes.Buffer
+	if err := Encode(exampleRecord{Name: "widget"}, &buf); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if buf.String() != "widget" {
+		t.Fatalf("unexpected encoded bytes: %q", buf.String())
+	}
+}
+
+func TestEncodeUnregisteredTypeErrors(t *testing.T) {
+	if err := Encode(struct{ Unregistered bool }{}, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected an error encoding a type with no registered TypeInfo")
+	}
+}
+
+func TestDecodeDispatchesToRegisteredDecoder(t *testing.T) {
+	registerExampleRecord(t)
+
+	v, err := Decode("test:example/registry-test#example-record", bytes.NewBufferString("widget"))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	rec, ok := v.(exampleRecord)
+	if !ok || rec.Name != "widget" {
+		t.Fatalf("unexpected decoded value: %#v", v)
+	}
+}
+
+func TestDecodeUnknownWITNameErrors(t *testing.T) {
+	if _, err := Decode("test:example/registry-test#does-not-exist", &bytes.Buffer{}); err == nil {
+		t.Fatal("expected an error decoding an unregistered WIT name")
+	}
+}
+
+func TestDecodePropagatesUnderlyingError(t *testing.T) {
+	wantErr := errors.New("boom")
+	Register(TypeInfo{
+		WITName: "test:example/registry-test#failing-record",
+		GoType:  reflect.TypeOf(struct{ Failing bool }{}),
+		Decode: func(r wrpc.ByteReader) (any, error) {
+			return nil, wantErr
+		},
+	})
+
+	_, err := Decode("test:example/registry-test#failing-record", &bytes.Buffer{})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the underlying decode error to propagate, got: %v", err)
+	}
+}